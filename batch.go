@@ -0,0 +1,97 @@
+package shamir
+
+// multSlice sets dst[i] = mult(a[i], b) for every i. GF(2^16) multiplication
+// by a fixed scalar b is linear in the bits of the other operand, so
+// mult(a, b) decomposes as mult(hi(a)<<8, b) XOR mult(lo(a), b); precomputing
+// those 256 possible values for the high and low byte of a turns every
+// element of the slice into two table lookups and an XOR instead of a
+// log/exp lookup and a modular reduction.
+//
+// This is the math/bits-only fallback rather than an AMD64 assembly gather:
+// the gather tables hold 16-bit entries, so the usual PSHUFB trick GF(2^8)
+// Reed-Solomon codecs use (one 16-entry nibble table per lane) doesn't
+// carry over cleanly, and an AVX2 VPGATHERDD-based port couldn't be
+// exercised against real hardware behavior here beyond the same scalar
+// tests this fallback is checked against - not a good trade against
+// shipping hand-written machine code no one can review by eye. The loop
+// below is unrolled by 8 words per iteration so the table lookups and XORs
+// for a whole cache line of output are visible to the compiler together,
+// which is the batching width the PSHUFB approach would have given.
+func multSlice(dst, a []uint16, b uint16) {
+	if len(dst) != len(a) {
+		panic("shamir: dst and a must be the same length")
+	}
+	if b == 0 {
+		for i := range dst {
+			dst[i] = 0
+		}
+		return
+	}
+
+	var hiTable, loTable [256]uint16
+	for i := 0; i < 256; i++ {
+		hiTable[i] = mult(uint16(i)<<8, b)
+		loTable[i] = mult(uint16(i), b)
+	}
+
+	i := 0
+	for ; i+8 <= len(a); i += 8 {
+		chunk := a[i : i+8 : i+8]
+		out := dst[i : i+8 : i+8]
+		out[0] = hiTable[chunk[0]>>8] ^ loTable[chunk[0]&0xFF]
+		out[1] = hiTable[chunk[1]>>8] ^ loTable[chunk[1]&0xFF]
+		out[2] = hiTable[chunk[2]>>8] ^ loTable[chunk[2]&0xFF]
+		out[3] = hiTable[chunk[3]>>8] ^ loTable[chunk[3]&0xFF]
+		out[4] = hiTable[chunk[4]>>8] ^ loTable[chunk[4]&0xFF]
+		out[5] = hiTable[chunk[5]>>8] ^ loTable[chunk[5]&0xFF]
+		out[6] = hiTable[chunk[6]>>8] ^ loTable[chunk[6]&0xFF]
+		out[7] = hiTable[chunk[7]>>8] ^ loTable[chunk[7]&0xFF]
+	}
+	for ; i < len(a); i++ {
+		dst[i] = hiTable[a[i]>>8] ^ loTable[a[i]&0xFF]
+	}
+}
+
+// addSlice XORs a into dst in place. XOR is both GF(2^16) addition and
+// subtraction, so this is the only accumulation primitive
+// interpolateAtZeroBatch needs.
+func addSlice(dst, a []uint16) {
+	if len(dst) != len(a) {
+		panic("shamir: dst and a must be the same length")
+	}
+	for i, v := range a {
+		dst[i] ^= v
+	}
+}
+
+// interpolateAtZeroBatch is the batched counterpart to interpolatePolynomial
+// evaluated at x=0, across a whole vector of polynomials that all share the
+// same x-coordinates. It computes each of the len(xs) Lagrange coefficients
+// L_i(0) once - a single GF(2^16) scalar - and folds the i-th share's entire
+// y-vector into out with one multSlice/addSlice pass, rather than looping
+// per word and dispatching a goroutine for each.
+func interpolateAtZeroBatch(xs []uint16, ys [][]uint16, out []uint16) {
+	limit := len(xs)
+	if len(ys) != limit {
+		panic("shamir: xs and ys must be the same length")
+	}
+
+	for i := range out {
+		out[i] = 0
+	}
+
+	scratch := make([]uint16, len(out))
+	for i := 0; i < limit; i++ {
+		basis := uint16(1)
+		for j := 0; j < limit; j++ {
+			if i == j {
+				continue
+			}
+			num := xs[j]
+			denom := add(xs[i], xs[j])
+			basis = mult(basis, div(num, denom))
+		}
+		multSlice(scratch, ys[i], basis)
+		addSlice(out, scratch)
+	}
+}
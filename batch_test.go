@@ -0,0 +1,82 @@
+package shamir
+
+import (
+	"testing"
+)
+
+func TestMultSlice(t *testing.T) {
+	a := []uint16{0, 1, 7, 300, 65535, 12345}
+	b := uint16(42)
+
+	dst := make([]uint16, len(a))
+	multSlice(dst, a, b)
+
+	for i, v := range a {
+		if exp := mult(v, b); dst[i] != exp {
+			t.Fatalf("bad: %d: got %v want %v", i, dst[i], exp)
+		}
+	}
+}
+
+func TestMultSlice_unrolledAndTail(t *testing.T) {
+	// 19 elements exercises two full 8-wide unrolled iterations plus a
+	// 3-element tail handled by the scalar loop.
+	a := make([]uint16, 19)
+	for i := range a {
+		a[i] = uint16(i*937 + 1)
+	}
+	b := uint16(777)
+
+	dst := make([]uint16, len(a))
+	multSlice(dst, a, b)
+
+	for i, v := range a {
+		if exp := mult(v, b); dst[i] != exp {
+			t.Fatalf("bad: %d: got %v want %v", i, dst[i], exp)
+		}
+	}
+}
+
+func TestMultSlice_zeroScalar(t *testing.T) {
+	a := []uint16{1, 2, 3}
+	dst := make([]uint16, len(a))
+	multSlice(dst, a, 0)
+
+	for _, v := range dst {
+		if v != 0 {
+			t.Fatalf("bad: %v", dst)
+		}
+	}
+}
+
+func TestAddSlice(t *testing.T) {
+	dst := []uint16{1, 2, 3}
+	a := []uint16{4, 5, 6}
+
+	addSlice(dst, a)
+
+	for i := range dst {
+		if exp := add(uint16(i+1), a[i]); dst[i] != exp {
+			t.Fatalf("bad: %d: got %v want %v", i, dst[i], exp)
+		}
+	}
+}
+
+func TestInterpolateAtZeroBatch_matchesScalar(t *testing.T) {
+	xs := []uint16{1, 2, 3}
+	ys := [][]uint16{
+		{10, 20, 30},
+		{11, 22, 33},
+		{12, 24, 36},
+	}
+
+	out := make([]uint16, 3)
+	interpolateAtZeroBatch(xs, ys, out)
+
+	for idx := 0; idx < 3; idx++ {
+		wordYs := []uint16{ys[0][idx], ys[1][idx], ys[2][idx]}
+		if exp := interpolatePolynomial(xs, wordYs, 0); out[idx] != exp {
+			t.Fatalf("word %d: got %v want %v", idx, out[idx], exp)
+		}
+	}
+}
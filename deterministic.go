@@ -0,0 +1,116 @@
+package shamir
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/PureStorage-OpenConnect/shamir-16/gf16"
+)
+
+// SplitDeterministic behaves like Split, except every random polynomial
+// coefficient is derived from seed and info via HKDF-SHA256 instead of
+// crypto/rand. The same (secret, parts, threshold, seed, info) always
+// produces byte-identical shares, which makes it possible to regenerate a
+// lost share from a backed-up seed, or to pin known-answer test vectors
+// across releases.
+//
+// seed must be kept exactly as secret as secret itself: anyone who learns
+// seed (and info, which is not assumed to be secret) can derive every
+// coefficient SplitDeterministic would, and with them, every share and the
+// original secret. Use crypto/rand-backed Split unless reproducibility is
+// specifically required.
+func SplitDeterministic(secret Secret, parts, threshold int, seed, info []byte) ([]Part, error) {
+	if parts < threshold {
+		return nil, fmt.Errorf("parts cannot be less than threshold")
+	}
+	if parts > MaxPartsGF16 {
+		return nil, fmt.Errorf("parts cannot exceed %d", MaxPartsGF16)
+	}
+	if threshold < 2 {
+		return nil, fmt.Errorf("threshold must be at least 2")
+	}
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("cannot split an empty secret")
+	}
+	if len(secret)%2 != 0 {
+		return nil, fmt.Errorf("cannot split odd length secret")
+	}
+	if len(seed) == 0 {
+		return nil, fmt.Errorf("seed cannot be empty")
+	}
+
+	secret16 := byteToUint16(secret)
+	prk := hkdfExtract(seed)
+
+	out16 := make([][]uint16, parts)
+	for idx := range out16 {
+		out16[idx] = make([]uint16, len(secret16)+1)
+		out16[idx][len(secret16)] = uint16(idx) + 1
+	}
+
+	for wordIndex, val := range secret16 {
+		p := deriveWordPolynomial(prk, info, wordIndex, val, threshold-1)
+
+		for i := 0; i < parts; i++ {
+			x := uint16(i) + 1
+			out16[i][wordIndex] = uint16(p.Evaluate(gf16.Element(x)))
+		}
+	}
+
+	out := make([]Part, 0, parts)
+	for _, array16 := range out16 {
+		out = append(out, uint16ToByte(array16))
+	}
+
+	return out, nil
+}
+
+// deriveWordPolynomial builds the polynomial used for the word at wordIndex:
+// intercept is the word's own value, and each coefficient of degree i is
+// HKDF-SHA256(seed, info || wordIndex || i), keeping every word's and every
+// coefficient's randomness domain-separated from the rest.
+func deriveWordPolynomial(prk, info []byte, wordIndex int, intercept uint16, degree int) gf16.Polynomial {
+	coefficients := make([]gf16.Element, degree+1)
+	coefficients[0] = gf16.Element(intercept)
+
+	for i := 1; i <= degree; i++ {
+		coefficients[i] = gf16.Element(binary.BigEndian.Uint16(hkdfExpand(prk, coefficientInfo(info, wordIndex, i), 2)))
+	}
+
+	return gf16.Polynomial{Coefficients: coefficients}
+}
+
+// coefficientInfo appends the big-endian word and coefficient indices to
+// info, giving HKDF-Expand a distinct info string for every coefficient of
+// every word's polynomial. wordIndex is encoded as a uint32: secrets larger
+// than 128KiB have more than 65536 words, and truncating wordIndex to
+// uint16 would derive identical coefficients for words whose indices
+// differ by exactly 65536.
+func coefficientInfo(info []byte, wordIndex, coeffIndex int) []byte {
+	out := make([]byte, len(info)+6)
+	copy(out, info)
+	binary.BigEndian.PutUint32(out[len(info):], uint32(wordIndex))
+	binary.BigEndian.PutUint16(out[len(info)+4:], uint16(coeffIndex))
+	return out
+}
+
+// hkdfExtract is the RFC 5869 HKDF-Extract step with an empty salt, since
+// SplitDeterministic has no separate salt input: seed itself is the only
+// secret input keying the derivation.
+func hkdfExtract(seed []byte) []byte {
+	mac := hmac.New(sha256.New, make([]byte, sha256.Size))
+	mac.Write(seed)
+	return mac.Sum(nil)
+}
+
+// hkdfExpand is the RFC 5869 HKDF-Expand step, specialized to the short
+// (<=2 byte) outputs deriveWordPolynomial needs, so it never has to loop
+// over multiple HMAC blocks.
+func hkdfExpand(prk, info []byte, length int) []byte {
+	mac := hmac.New(sha256.New, prk)
+	mac.Write(info)
+	mac.Write([]byte{1})
+	return mac.Sum(nil)[:length]
+}
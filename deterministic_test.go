@@ -0,0 +1,129 @@
+package shamir
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// TestSplitDeterministic_KAT pins SplitDeterministic's output for a fixed
+// (secret, seed, info) so that a change to the HKDF derivation, however
+// well-intentioned, shows up as a failing test rather than silently
+// reshuffling everyone's existing backups.
+func TestSplitDeterministic_KAT(t *testing.T) {
+	secret := Secret("deterministic test secret!")
+	seed := []byte("this is a very secret seed value")
+	info := []byte("shamir-16 kat v1")
+
+	want := []string{
+		"88566956f03bf9669c722b60091f2d50b38cdcf67c18392896530001",
+		"368b43967871511c67efab5a2ae04531c0eefd3a12c9cb2d1d150002",
+		"dab85ea5fa27c11492eef45340df1c04001601bf0bb28060ff670003",
+		"8ba32dd71cb9382e96024e8ba64e3a238dcf5bca8f4cafb671330004",
+		"679030e49eefa82663031182cc7163164d37a74f9637e4fb93410005",
+	}
+
+	parts, err := SplitDeterministic(secret, 5, 3, seed, info)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(parts) != len(want) {
+		t.Fatalf("got %d parts, want %d", len(parts), len(want))
+	}
+	for i, part := range parts {
+		gotHex := hex.EncodeToString(part)
+		if gotHex != want[i] {
+			t.Fatalf("part %d: got %s, want %s", i, gotHex, want[i])
+		}
+	}
+
+	secret2, err := Combine(parts[:3])
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !bytes.Equal(secret2, secret) {
+		t.Fatalf("combined secret does not match original")
+	}
+}
+
+func TestSplitDeterministic_repeatable(t *testing.T) {
+	secret := Secret("another test secret!!!")
+	seed := []byte("seed phrase goes here")
+	info := []byte("info string")
+
+	parts1, err := SplitDeterministic(secret, 4, 2, seed, info)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	parts2, err := SplitDeterministic(secret, 4, 2, seed, info)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	for i := range parts1 {
+		if !bytes.Equal(parts1[i], parts2[i]) {
+			t.Fatalf("part %d differs between runs", i)
+		}
+	}
+
+	otherSeed := []byte("a completely different seed!!!!")
+	parts3, err := SplitDeterministic(secret, 4, 2, otherSeed, info)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if bytes.Equal(parts1[0], parts3[0]) {
+		t.Fatalf("different seeds produced the same share")
+	}
+}
+
+func TestSplitDeterministic_invalid(t *testing.T) {
+	secret := Secret("test")
+	seed := []byte("seed")
+	info := []byte("info")
+
+	if _, err := SplitDeterministic(secret, 2, 3, seed, info); err == nil {
+		t.Fatalf("expect error")
+	}
+	if _, err := SplitDeterministic(secret, 10, 1, seed, info); err == nil {
+		t.Fatalf("expect error")
+	}
+	if _, err := SplitDeterministic(nil, 3, 2, seed, info); err == nil {
+		t.Fatalf("expect error")
+	}
+	if _, err := SplitDeterministic(Secret("tes"), 2, 2, seed, info); err == nil {
+		t.Fatalf("expect error")
+	}
+	if _, err := SplitDeterministic(secret, 3, 2, nil, info); err == nil {
+		t.Fatalf("expect error")
+	}
+}
+
+// TestSplitDeterministic_wordIndexBeyond64K guards against wordIndex being
+// truncated to a uint16 before being folded into the HKDF info string,
+// which would derive identical coefficients for any two words whose
+// indices differ by exactly 65536.
+func TestSplitDeterministic_wordIndexBeyond64K(t *testing.T) {
+	const farWord = 65536
+	secret16 := make([]uint16, farWord+1)
+	secret16[0] = 1
+	secret16[farWord] = 2
+	secret := Secret(uint16ToByte(secret16))
+
+	seed := []byte("this is a very secret seed value")
+	info := []byte("shamir-16 kat v1")
+
+	parts, err := SplitDeterministic(secret, 2, 2, seed, info)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	diffAtX := func(part Part) uint16 {
+		words := byteToUint16(part)
+		return words[0] ^ words[farWord]
+	}
+
+	diff1 := diffAtX(parts[0])
+	diff2 := diffAtX(parts[1])
+	if diff1 == diff2 {
+		t.Fatalf("word 0 and word %d derived the same coefficient: both shares gave XOR %#x", farWord, diff1)
+	}
+}
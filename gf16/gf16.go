@@ -0,0 +1,63 @@
+// Package gf16 implements GF(2^16), the finite field that package shamir
+// secret-shares over. It is split out so that other threshold-cryptography
+// schemes - distributed key generation, threshold signatures, PVSS,
+// oblivious PRFs, and so on - can reuse the same log/exp tables and
+// polynomial machinery instead of reimplementing them; package shamir's
+// Split and Combine are themselves thin wrappers over this package.
+package gf16
+
+import "crypto/subtle"
+
+const (
+	// Size is the number of elements in GF(2^16).
+	Size = 65536
+
+	// Modulo is the order of the field's multiplicative group, used when
+	// reducing sums and differences of discrete logarithms.
+	Modulo = Size - 1
+)
+
+// Element is a single value in GF(2^16).
+type Element uint16
+
+// Add combines two elements in GF(2^16). This can also be used for
+// subtraction since it is symmetric.
+func Add(a, b Element) Element {
+	return a ^ b
+}
+
+// Mul multiplies two elements of GF(2^16).
+func Mul(a, b Element) Element {
+	logA := logTable[a]
+	logB := logTable[b]
+	sum := (int(logA) + int(logB)) % Modulo
+
+	ret := int(expTable[sum])
+
+	// Ensure we return zero if either a or b are zero but aren't subject to
+	// timing attacks
+	ret = subtle.ConstantTimeSelect(subtle.ConstantTimeEq(int32(a), 0), 0, ret)
+	ret = subtle.ConstantTimeSelect(subtle.ConstantTimeEq(int32(b), 0), 0, ret)
+
+	return Element(ret)
+}
+
+// Div divides two elements of GF(2^16).
+func Div(a, b Element) Element {
+	if b == 0 {
+		// leaks some timing information but we don't care anyways as this
+		// should never happen, hence the panic
+		panic("divide by zero")
+	}
+
+	logA := logTable[a]
+	logB := logTable[b]
+	diff := ((int(logA) - int(logB)) + Modulo) % Modulo
+
+	ret := int(expTable[diff])
+
+	// Ensure we return zero if a is zero but aren't subject to timing
+	// attacks
+	ret = subtle.ConstantTimeSelect(subtle.ConstantTimeEq(int32(a), 0), 0, ret)
+	return Element(ret)
+}
@@ -0,0 +1,168 @@
+package gf16
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Polynomial is a polynomial over GF(2^16). Coefficients[i] is the
+// coefficient of x^i, so Coefficients[0] is the intercept (the value of the
+// polynomial at x=0) and len(Coefficients)-1 is its degree.
+type Polynomial struct {
+	Coefficients []Element
+}
+
+// Random constructs a polynomial of the given degree with the provided
+// intercept and the rest of its coefficients drawn from rand. Split uses
+// crypto/rand; tests and deterministic KATs can pass anything else that
+// implements io.Reader.
+func Random(intercept Element, degree int, rand io.Reader) (Polynomial, error) {
+	p := Polynomial{Coefficients: make([]Element, degree+1)}
+	p.Coefficients[0] = intercept
+
+	raw := make([]byte, degree*2)
+	if _, err := io.ReadFull(rand, raw); err != nil {
+		return p, err
+	}
+	for i := 0; i < degree; i++ {
+		p.Coefficients[i+1] = Element(binary.BigEndian.Uint16(raw[2*i : 2*i+2]))
+	}
+
+	return p, nil
+}
+
+// Evaluate returns the value of the polynomial at the given x, using
+// Horner's method.
+func (p Polynomial) Evaluate(x Element) Element {
+	if x == 0 {
+		return p.Coefficients[0]
+	}
+
+	degree := len(p.Coefficients) - 1
+	out := p.Coefficients[degree]
+	for i := degree - 1; i >= 0; i-- {
+		out = Add(Mul(out, x), p.Coefficients[i])
+	}
+	return out
+}
+
+// Interpolate takes len(xSamples) sample points and returns the value of
+// the unique polynomial passing through them at the given x, using
+// Lagrange interpolation.
+func Interpolate(xSamples, ySamples []Element, x Element) Element {
+	limit := len(xSamples)
+	var result, basis Element
+	for i := 0; i < limit; i++ {
+		basis = 1
+		for j := 0; j < limit; j++ {
+			if i == j {
+				continue
+			}
+			num := Add(x, xSamples[j])
+			denom := Add(xSamples[i], xSamples[j])
+			term := Div(num, denom)
+			basis = Mul(basis, term)
+		}
+		group := Mul(ySamples[i], basis)
+		result = Add(result, group)
+	}
+	return result
+}
+
+// degree returns the index of p's highest non-zero coefficient, or -1 for
+// the zero polynomial.
+func (p Polynomial) degree() int {
+	for i := len(p.Coefficients) - 1; i >= 0; i-- {
+		if p.Coefficients[i] != 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// Add returns p + q. Since GF(2^16) addition is its own inverse, this is
+// also polynomial subtraction.
+func (p Polynomial) Add(q Polynomial) Polynomial {
+	n := len(p.Coefficients)
+	if len(q.Coefficients) > n {
+		n = len(q.Coefficients)
+	}
+	out := make([]Element, n)
+	for i := range out {
+		var a, b Element
+		if i < len(p.Coefficients) {
+			a = p.Coefficients[i]
+		}
+		if i < len(q.Coefficients) {
+			b = q.Coefficients[i]
+		}
+		out[i] = Add(a, b)
+	}
+	return Polynomial{Coefficients: out}
+}
+
+// Mul returns p * q, computed by convolving their coefficients.
+func (p Polynomial) Mul(q Polynomial) Polynomial {
+	pd, qd := p.degree(), q.degree()
+	if pd < 0 || qd < 0 {
+		return Polynomial{Coefficients: []Element{0}}
+	}
+
+	out := make([]Element, pd+qd+1)
+	for i, a := range p.Coefficients[:pd+1] {
+		if a == 0 {
+			continue
+		}
+		for j, b := range q.Coefficients[:qd+1] {
+			out[i+j] = Add(out[i+j], Mul(a, b))
+		}
+	}
+	return Polynomial{Coefficients: out}
+}
+
+// Divmod divides p by divisor and returns the quotient and remainder such
+// that p == quotient*divisor + remainder and remainder's degree is less
+// than divisor's, using GF(2^16) polynomial long division. It errors if
+// divisor is the zero polynomial.
+func (p Polynomial) Divmod(divisor Polynomial) (quotient, remainder Polynomial, err error) {
+	divDeg := divisor.degree()
+	if divDeg < 0 {
+		return Polynomial{}, Polynomial{}, fmt.Errorf("division by the zero polynomial")
+	}
+
+	remainder = Polynomial{Coefficients: append([]Element{}, p.Coefficients...)}
+	quotientCoeffs := make([]Element, 0)
+
+	for {
+		remDeg := remainder.degree()
+		if remDeg < divDeg {
+			break
+		}
+
+		coeff := Div(remainder.Coefficients[remDeg], divisor.Coefficients[divDeg])
+		shift := remDeg - divDeg
+
+		for len(quotientCoeffs) <= shift {
+			quotientCoeffs = append(quotientCoeffs, 0)
+		}
+		quotientCoeffs[shift] = coeff
+
+		for i, c := range divisor.Coefficients[:divDeg+1] {
+			remainder.Coefficients[shift+i] = Add(remainder.Coefficients[shift+i], Mul(coeff, c))
+		}
+	}
+
+	if len(quotientCoeffs) == 0 {
+		quotientCoeffs = []Element{0}
+	}
+
+	remDeg := remainder.degree()
+	if remDeg < 0 {
+		remainder = Polynomial{Coefficients: []Element{0}}
+	} else {
+		remainder = Polynomial{Coefficients: remainder.Coefficients[:remDeg+1]}
+	}
+
+	return Polynomial{Coefficients: quotientCoeffs}, remainder, nil
+}
@@ -0,0 +1,94 @@
+package gf16
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPolynomial_Add(t *testing.T) {
+	p := Polynomial{Coefficients: []Element{1, 2, 3}}
+	q := Polynomial{Coefficients: []Element{4, 5}}
+
+	got := p.Add(q)
+	want := []Element{Add(1, 4), Add(2, 5), 3}
+	if !elementsEqual(got.Coefficients, want) {
+		t.Fatalf("bad: %v, want %v", got.Coefficients, want)
+	}
+
+	// Adding is its own inverse in GF(2^16).
+	back := got.Add(q)
+	if !elementsEqual(back.Coefficients, p.Coefficients) {
+		t.Fatalf("bad: %v, want %v", back.Coefficients, p.Coefficients)
+	}
+}
+
+func TestPolynomial_Mul(t *testing.T) {
+	// (x + 1) * (x + 1) = x^2 + 1, since 2*Mul(a,b) cancels under XOR.
+	p := Polynomial{Coefficients: []Element{1, 1}}
+	got := p.Mul(p)
+	want := []Element{1, 0, 1}
+	if !elementsEqual(got.Coefficients, want) {
+		t.Fatalf("bad: %v, want %v", got.Coefficients, want)
+	}
+
+	zero := Polynomial{Coefficients: []Element{0}}
+	if got := p.Mul(zero); !elementsEqual(got.Coefficients, []Element{0}) {
+		t.Fatalf("expected multiplying by zero to give zero, got %v", got.Coefficients)
+	}
+}
+
+func TestPolynomial_Divmod(t *testing.T) {
+	if _, _, err := (Polynomial{Coefficients: []Element{1}}).Divmod(Polynomial{Coefficients: []Element{0}}); err == nil {
+		t.Fatalf("expected division by the zero polynomial to error")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 200; trial++ {
+		p := randomPolynomial(r, r.Intn(8)+1)
+		divisor := randomPolynomial(r, r.Intn(4)+1)
+
+		quotient, remainder, err := p.Divmod(divisor)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+
+		reconstructed := quotient.Mul(divisor).Add(remainder)
+		if !elementsEqual(trimLeadingZeros(reconstructed.Coefficients), trimLeadingZeros(p.Coefficients)) {
+			t.Fatalf("trial %d: quotient*divisor + remainder = %v, want %v", trial, reconstructed.Coefficients, p.Coefficients)
+		}
+		if remainder.degree() >= divisor.degree() {
+			t.Fatalf("trial %d: remainder degree %d not less than divisor degree %d", trial, remainder.degree(), divisor.degree())
+		}
+	}
+}
+
+func randomPolynomial(r *rand.Rand, degree int) Polynomial {
+	coeffs := make([]Element, degree+1)
+	for i := range coeffs {
+		coeffs[i] = Element(r.Intn(Size))
+	}
+	if coeffs[degree] == 0 {
+		coeffs[degree] = 1
+	}
+	return Polynomial{Coefficients: coeffs}
+}
+
+func trimLeadingZeros(coeffs []Element) []Element {
+	n := len(coeffs)
+	for n > 1 && coeffs[n-1] == 0 {
+		n--
+	}
+	return coeffs[:n]
+}
+
+func elementsEqual(a, b []Element) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
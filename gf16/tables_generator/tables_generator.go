@@ -8,10 +8,10 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/PureStorage-OpenConnect/shamir-16"
+	"github.com/PureStorage-OpenConnect/shamir-16/gf16"
 )
 
-var placeholder_header string = `package shamir
+var placeholder_header string = `package gf16
 
 // Tables generated tables_generator.go
 // They use 'x^16 + x^12 + x^3 + x^1 + 1' or '0x100B' as the generator
@@ -77,12 +77,12 @@ func tableGenerator() error {
 	var product uint16 = generator
 	var err error
 
-	expTable := make([]uint16, 0, shamir.SizeGF16)
+	expTable := make([]uint16, 0, gf16.Size)
 	expTable = append(expTable, 1)
 	expTable = append(expTable, product)
 	count := 2 // Number of currently generated elements of multiplicative group
 
-	logTable := make([]uint16, shamir.SizeGF16)
+	logTable := make([]uint16, gf16.Size)
 	logTable[product] = 1
 
 	for product != 1 {
@@ -93,7 +93,7 @@ func tableGenerator() error {
 	}
 
 	// Test that generator was correctly selected
-	if count != shamir.SizeGF16 {
+	if count != gf16.Size {
 		_, err = os.Stderr.WriteString("Incorrectly selected generator! Please, select a different one and try again.")
 		return err
 	}
@@ -1,4 +1,4 @@
-package shamir
+package gf16
 
 import (
 	"testing"
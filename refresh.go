@@ -0,0 +1,157 @@
+package shamir
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+)
+
+// Refresh re-randomizes a quorum of shares without changing the secret they
+// reconstruct or their (x-index, threshold). In a real multi-party setting
+// each participant would sample their own zero-intercept polynomial delta(x)
+// and the shares would add up via a round of communication; in the
+// single-dealer setting exposed here this is done centrally by sampling one
+// zero-intercept polynomial per 16-bit word of the secret and adding its
+// evaluation onto every part's existing y-value for that word. A share
+// stolen before Refresh is worthless against the returned shares: it lies on
+// the old polynomial, not the new one, and combining it with refreshed
+// shares reconstructs nothing meaningful.
+func Refresh(parts []Part, threshold int) ([]Part, error) {
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("less than two parts cannot be refreshed")
+	}
+	if threshold < 2 {
+		return nil, fmt.Errorf("threshold must be at least 2")
+	}
+
+	firstPartLen := len(parts[0])
+	if firstPartLen < 4 {
+		return nil, fmt.Errorf("parts must be at least four bytes")
+	}
+	if firstPartLen%2 != 0 {
+		return nil, fmt.Errorf("parts must be even bytes long")
+	}
+	for i := 1; i < len(parts); i++ {
+		if len(parts[i]) != firstPartLen {
+			return nil, fmt.Errorf("all parts must be the same length")
+		}
+	}
+
+	numWords := firstPartLen/2 - 1
+	parts16 := make([][]uint16, len(parts))
+	xSamples := make([]uint16, len(parts))
+	seen := make(map[uint16]struct{}, len(parts))
+	for i, part := range parts {
+		words := byteToUint16(part)
+		x := words[numWords]
+		if _, exists := seen[x]; exists {
+			return nil, fmt.Errorf("duplicate part detected")
+		}
+		seen[x] = struct{}{}
+		xSamples[i] = x
+		parts16[i] = words
+	}
+
+	for idx := 0; idx < numWords; idx++ {
+		delta, err := makePolynomial(0, uint16(threshold-1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate refresh polynomial: %w", err)
+		}
+		for i, x := range xSamples {
+			parts16[i][idx] = add(parts16[i][idx], delta.evaluate(x))
+		}
+	}
+
+	out := make([]Part, len(parts))
+	for i, words := range parts16 {
+		out[i] = uint16ToByte(words)
+	}
+	return out, nil
+}
+
+// Epoch tags the generation of shares a Reshare call produced. It is drawn
+// independently from crypto/rand, not derived from the new x-index
+// namespace: two Reshare calls over different quorum subsets of the same
+// source generation can land on the same maxX (e.g. both subsets include
+// the same highest-indexed old share), which would make an x-index-derived
+// Epoch collide too, defeating CombineGeneration's whole point. A random
+// 64-bit tag makes an accidental collision between independent reshares
+// negligible.
+type Epoch uint64
+
+// randomEpoch returns a fresh, independent Epoch.
+func randomEpoch() (Epoch, error) {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return 0, fmt.Errorf("failed to generate epoch: %w", err)
+	}
+	return Epoch(binary.BigEndian.Uint64(buf[:])), nil
+}
+
+// GenerationPart is a share produced by Reshare: an ordinary Shamir Part
+// tagged with the epoch of the reshare that produced it.
+type GenerationPart struct {
+	Epoch Epoch
+	Part  Part
+}
+
+// Reshare reconstructs the secret from parts and re-splits it into a new
+// generation of newParts shares, newThreshold of which are required to
+// reconstruct. Unlike Refresh, Reshare can change the threshold and the
+// number of shareholders. The new generation's x-indices are assigned
+// strictly above every x-index used by parts, and are additionally tagged
+// with a fresh, independently-random Epoch, so CombineGeneration can reject
+// a share from a different generation outright rather than relying solely
+// on x-indices never colliding.
+func Reshare(parts []Part, newParts, newThreshold int) ([]GenerationPart, error) {
+	secret, err := Combine(parts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct secret: %w", err)
+	}
+
+	var maxX uint16
+	for _, part := range parts {
+		words := byteToUint16(part)
+		if x := words[len(words)-1]; x > maxX {
+			maxX = x
+		}
+	}
+
+	rawParts, err := splitFromX(secret, newParts, newThreshold, maxX)
+	if err != nil {
+		return nil, err
+	}
+
+	epoch, err := randomEpoch()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]GenerationPart, len(rawParts))
+	for i, p := range rawParts {
+		out[i] = GenerationPart{Epoch: epoch, Part: p}
+	}
+	return out, nil
+}
+
+// CombineGeneration reverses Reshare. It requires every part to carry the
+// same Epoch before reconstructing, so a share from a different reshare
+// generation - or a share from the quorum Reshare replaced, which carries
+// no epoch at all - is rejected outright instead of being silently
+// interpolated into the wrong secret the way passing raw Parts straight to
+// Combine would be.
+func CombineGeneration(parts []GenerationPart) (Secret, error) {
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("no parts provided")
+	}
+
+	epoch := parts[0].Epoch
+	plain := make([]Part, len(parts))
+	for i, p := range parts {
+		if p.Epoch != epoch {
+			return nil, fmt.Errorf("part %d has epoch %d, expected %d", i, p.Epoch, epoch)
+		}
+		plain[i] = p.Part
+	}
+	return Combine(plain)
+}
@@ -0,0 +1,141 @@
+package shamir
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRefresh(t *testing.T) {
+	secret := Secret("test")
+
+	out, err := Split(secret, 5, 3)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	refreshed, err := Refresh(out, 3)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(refreshed) != len(out) {
+		t.Fatalf("bad: %v", refreshed)
+	}
+
+	// Any threshold of the refreshed shares still recovers the secret.
+	recomb, err := Combine(refreshed[:3])
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !bytes.Equal(recomb, secret) {
+		t.Fatalf("bad: %v %v", recomb, secret)
+	}
+
+	// Mixing pre- and post-refresh shares does not recover the secret: the
+	// stolen share no longer lies on the refreshed polynomial.
+	mixed := []Part{out[0], refreshed[1], refreshed[2]}
+	recomb, err = Combine(mixed)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if bytes.Equal(recomb, secret) {
+		t.Fatalf("expected mixing pre- and post-refresh shares to fail to recover the secret")
+	}
+}
+
+func TestReshare(t *testing.T) {
+	secret := Secret("test")
+
+	out, err := Split(secret, 5, 3)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	resharedParts, err := Reshare(out[:3], 7, 4)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(resharedParts) != 7 {
+		t.Fatalf("bad: %v", resharedParts)
+	}
+
+	recomb, err := CombineGeneration(resharedParts[:4])
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !bytes.Equal(recomb, secret) {
+		t.Fatalf("bad: %v %v", recomb, secret)
+	}
+
+	// Old and new generation x-indices cannot collide.
+	oldXs := make(map[uint16]struct{})
+	for _, p := range out[:3] {
+		words := byteToUint16(p)
+		oldXs[words[len(words)-1]] = struct{}{}
+	}
+	for _, p := range resharedParts {
+		words := byteToUint16(p.Part)
+		if _, exists := oldXs[words[len(words)-1]]; exists {
+			t.Fatalf("reshared x-index collides with old generation")
+		}
+	}
+
+	// A second reshare produces a distinct epoch, and CombineGeneration
+	// rejects mixing shares across the two generations outright instead of
+	// silently reconstructing garbage.
+	resharedAgain, err := Reshare(rawParts(resharedParts[:4]), 5, 3)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if resharedAgain[0].Epoch == resharedParts[0].Epoch {
+		t.Fatalf("expected a fresh epoch for the second reshare")
+	}
+
+	mixedGenerations := []GenerationPart{resharedParts[0], resharedAgain[1], resharedAgain[2]}
+	if _, err := CombineGeneration(mixedGenerations); err == nil {
+		t.Fatalf("expected CombineGeneration to reject shares from different generations")
+	}
+}
+
+// TestReshare_sameMaxXDistinctEpoch guards against deriving Epoch from the
+// new x-index namespace: two Reshare calls over different quorum subsets
+// of the same source generation can land on the same maxX (here, both
+// subsets include the highest-indexed old share), which must not make
+// their epochs, or their CombineGeneration behavior, collide.
+func TestReshare_sameMaxXDistinctEpoch(t *testing.T) {
+	secret := Secret("test")
+
+	out, err := Split(secret, 5, 3)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	subsetA := []Part{out[0], out[1], out[4]}
+	subsetB := []Part{out[2], out[3], out[4]}
+
+	reshareA, err := Reshare(subsetA, 4, 3)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	reshareB, err := Reshare(subsetB, 4, 3)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	if reshareA[0].Epoch == reshareB[0].Epoch {
+		t.Fatalf("expected independently-reshared generations to have distinct epochs")
+	}
+
+	mixed := []GenerationPart{reshareA[0], reshareA[1], reshareB[0]}
+	recomb, err := CombineGeneration(mixed)
+	if err == nil {
+		t.Fatalf("expected CombineGeneration to reject shares mixed across generations, got %q", recomb)
+	}
+}
+
+func rawParts(generation []GenerationPart) []Part {
+	out := make([]Part, len(generation))
+	for i, g := range generation {
+		out[i] = g.Part
+	}
+	return out
+}
@@ -3,9 +3,10 @@ package shamir
 import (
 	"bytes"
 	"crypto/rand"
-	"crypto/subtle"
 	"encoding/binary"
 	"fmt"
+
+	"github.com/PureStorage-OpenConnect/shamir-16/gf16"
 )
 
 const (
@@ -47,104 +48,65 @@ func byteToUint16(s []byte) []uint16 {
 
 // makePolynomial constructs a random polynomial of the given
 // degree but with the provided intercept value.
+//
+// This, along with evaluate/interpolatePolynomial/add/mult/div below, is a
+// thin wrapper over package gf16, which holds the actual field and
+// polynomial arithmetic so that other threshold-cryptography code can reuse
+// it without depending on package shamir.
 func makePolynomial(intercept, degree uint16) (polynomial, error) {
-	// Create a wrapper
-	p := polynomial{
-		coefficients: make([]uint16, 1),
+	gp, err := gf16.Random(gf16.Element(intercept), int(degree), rand.Reader)
+	if err != nil {
+		return polynomial{}, err
 	}
 
-	// Ensure the intercept is set
-	p.coefficients[0] = intercept
-
-	// Assign random co-efficients to the polynomial
-	coefficients8 := make([]byte, degree*2)
-	if _, err := rand.Read(coefficients8); err != nil {
-		return p, err
+	coefficients := make([]uint16, len(gp.Coefficients))
+	for i, c := range gp.Coefficients {
+		coefficients[i] = uint16(c)
 	}
-
-	p.coefficients = append(p.coefficients, byteToUint16(coefficients8)...)
-
-	return p, nil
+	return polynomial{coefficients: coefficients}, nil
 }
 
 // evaluate returns the value of the polynomial for the given x
 func (p *polynomial) evaluate(x uint16) uint16 {
-	// Special case the origin
-	if x == 0 {
-		return p.coefficients[0]
-	}
+	return uint16(toGF16Polynomial(p.coefficients).Evaluate(gf16.Element(x)))
+}
 
-	// Compute the polynomial value using Horner's method.
-	degree := len(p.coefficients) - 1
-	out := p.coefficients[degree]
-	for i := degree - 1; i >= 0; i-- {
-		coeff := p.coefficients[i]
-		out = add(mult(out, x), coeff)
+func toGF16Polynomial(coefficients []uint16) gf16.Polynomial {
+	out := make([]gf16.Element, len(coefficients))
+	for i, c := range coefficients {
+		out[i] = gf16.Element(c)
 	}
-	return out
+	return gf16.Polynomial{Coefficients: out}
 }
 
 // interpolatePolynomial takes N sample points and returns
 // the value at a given x using a lagrange interpolation.
 func interpolatePolynomial(x_samples, y_samples []uint16, x uint16) uint16 {
-	limit := len(x_samples)
-	var result, basis uint16
-	for i := 0; i < limit; i++ {
-		basis = 1
-		for j := 0; j < limit; j++ {
-			if i == j {
-				continue
-			}
-			num := add(x, x_samples[j])
-			denom := add(x_samples[i], x_samples[j])
-			term := div(num, denom)
-			basis = mult(basis, term)
-		}
-		group := mult(y_samples[i], basis)
-		result = add(result, group)
+	xs := make([]gf16.Element, len(x_samples))
+	for i, v := range x_samples {
+		xs[i] = gf16.Element(v)
 	}
-	return result
+	ys := make([]gf16.Element, len(y_samples))
+	for i, v := range y_samples {
+		ys[i] = gf16.Element(v)
+	}
+	return uint16(gf16.Interpolate(xs, ys, gf16.Element(x)))
 }
 
 // div divides two numbers in GF(2^16)
 func div(a, b uint16) uint16 {
-	if b == 0 {
-		// leaks some timing information but we don't care anyways as this
-		// should never happen, hence the panic
-		panic("divide by zero")
-	}
-
-	log_a := logTable[a]
-	log_b := logTable[b]
-	diff := ((int(log_a) - int(log_b)) + ModuloGF16) % ModuloGF16
-
-	ret := int(expTable[diff])
-
-	// Ensure we return zero if a is zero but aren't subject to timing attacks
-	ret = subtle.ConstantTimeSelect(subtle.ConstantTimeEq(int32(a), 0), 0, ret)
-	return uint16(ret)
+	return uint16(gf16.Div(gf16.Element(a), gf16.Element(b)))
 }
 
 // mult multiplies two numbers in GF(2^16)
 func mult(a, b uint16) uint16 {
-	log_a := logTable[a]
-	log_b := logTable[b]
-	sum := (int(log_a) + int(log_b)) % ModuloGF16
-
-	ret := int(expTable[sum])
-
-	// Ensure we return zero if either a or b are zero but aren't subject to
-	// timing attacks
-	ret = subtle.ConstantTimeSelect(subtle.ConstantTimeEq(int32(a), 0), 0, ret)
-	ret = subtle.ConstantTimeSelect(subtle.ConstantTimeEq(int32(b), 0), 0, ret)
-
-	return uint16(ret)
+	return uint16(gf16.Mul(gf16.Element(a), gf16.Element(b)))
 }
 
 // add combines two numbers in GF(2^16)
 // This can also be used for subtraction since it is symmetric.
 func add(a, b uint16) uint16 {
-	return a ^ b
+	return uint16(gf16.Add(gf16.Element(a), gf16.Element(b)))
 }
 
 // Split takes an arbitrarily long secret with even length and generates a `parts`
@@ -153,6 +115,14 @@ func add(a, b uint16) uint16 {
 // than 'sizeGF16'. The returned shares are each one word longer than the secret
 // as they attach a tag used to reconstruct the secret.
 func Split(secret Secret, parts, threshold int) ([]Part, error) {
+	return splitFromX(secret, parts, threshold, 0)
+}
+
+// splitFromX is the shared implementation behind Split and Reshare. It is
+// identical to Split except that x-indices start at baseX+1 instead of 1,
+// which lets Reshare hand out a new generation of shares whose x-indices
+// cannot collide with the quorum it is replacing.
+func splitFromX(secret Secret, parts, threshold int, baseX uint16) ([]Part, error) {
 	// Cannot heve less parts than is the threshold
 	if parts < threshold {
 		return nil, fmt.Errorf("parts cannot be less than threshold")
@@ -161,6 +131,9 @@ func Split(secret Secret, parts, threshold int) ([]Part, error) {
 	if parts > MaxPartsGF16 {
 		return nil, fmt.Errorf("parts cannot exceed %d", MaxPartsGF16)
 	}
+	if int(baseX)+parts > MaxPartsGF16 {
+		return nil, fmt.Errorf("no room left in the x-index namespace for %d parts", parts)
+	}
 	// Threshold of 1 makes no sense
 	if threshold < 2 {
 		return nil, fmt.Errorf("threshold must be at least 2")
@@ -183,7 +156,7 @@ func Split(secret Secret, parts, threshold int) ([]Part, error) {
 	out16 := make([][]uint16, parts)
 	for idx := range out16 {
 		out16[idx] = make([]uint16, len(secret16)+1)
-		out16[idx][len(secret16)] = uint16(idx) + 1
+		out16[idx][len(secret16)] = baseX + uint16(idx) + 1
 	}
 
 	// Construct a random polynomial for each word of the secret.
@@ -200,7 +173,7 @@ func Split(secret Secret, parts, threshold int) ([]Part, error) {
 		// We cheat by encoding the x value once as the final index,
 		// so that it only needs to be stored once.
 		for i := 0; i < parts; i++ {
-			x := uint16(i) + 1
+			x := baseX + uint16(i) + 1
 			y := p.evaluate(x)
 			out16[i][idx] = y
 		}
@@ -252,6 +225,7 @@ func Combine(parts []Part) (Secret, error) {
 	// Set the x value for each sample and ensure no x_sample values are the same,
 	// otherwise div() can be unhappy
 	checkMap := make(map[uint16]struct{}, len(parts16))
+	y_samples := make([][]uint16, len(parts16))
 	for i, part := range parts16 {
 		samp := part[len(part)-1]
 		if _, exists := checkMap[samp]; exists {
@@ -259,45 +233,13 @@ func Combine(parts []Part) (Secret, error) {
 		}
 		checkMap[samp] = struct{}{}
 		x_samples[i] = samp
+		y_samples[i] = part[:len(secret16)]
 	}
 
-	queue := make(chan struct {
-		int
-		uint16
-	})
-
-	// Reconstruct each word
-	for idx := range secret16 {
-
-		// Compute every word of secret in separate goroutine.
-		// Experimentally, this is about 2x as fast for GOMAXPROCS => 8,
-		// with virtually no overhead if parallelization is not an option.
-		go func(queue chan struct {
-			int
-			uint16
-		}, parts16 [][]uint16, x_samples []uint16, idx int) {
-			y_samples := make([]uint16, len(parts))
-
-			// Set the y value for each sample
-			for i, part := range parts16 {
-				y_samples[i] = part[idx]
-			}
-
-			// Interpolate the polynomial and compute the value at 0
-			val := interpolatePolynomial(x_samples, y_samples, 0)
-
-			queue <- struct {
-				int
-				uint16
-			}{idx, val}
-		}(queue, parts16, x_samples, idx)
-	}
-
-	// Evaluate the 0th value to get the intercept
-	for range secret16 {
-		result := <-queue
-		secret16[result.int] = result.uint16
-	}
+	// Interpolate every word of the secret at once: one Lagrange
+	// coefficient per share, folded across the whole share in a single
+	// linear pass, rather than one goroutine per word.
+	interpolateAtZeroBatch(x_samples, y_samples, secret16)
 
 	return uint16ToByte(secret16), nil
 }
@@ -0,0 +1,360 @@
+package shamir
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+)
+
+const (
+	// streamMagic identifies a share produced by SplitStream, distinguishing
+	// it from the in-memory Part format which carries no header of its own.
+	streamMagic uint16 = 0x5348 // "SH"
+
+	streamVersion uint8 = 1
+
+	// DefaultBlockSize is the amount of plaintext each stream block carries.
+	// Memory use of SplitStream/CombineStream is O(DefaultBlockSize * parts)
+	// regardless of the size of the secret being streamed.
+	DefaultBlockSize = 64 * 1024
+
+	// MaxBlockSize is the largest blockSize CombineStream will accept from a
+	// share header. blockSize is read from untrusted input and, unlike the
+	// per-block length prefix, was previously only checked for agreement
+	// across shares, never against any hard ceiling - so a set of shares
+	// that agreed on an arbitrary multi-gigabyte blockSize could still force
+	// a multi-gigabyte allocation per block. SplitStream only ever writes
+	// DefaultBlockSize, so this has no effect on shares it produces.
+	MaxBlockSize = DefaultBlockSize
+
+	// streamHeaderSize is the fixed size, in bytes, of the per-share header
+	// written once at the start of every stream: magic(2) version(1) x(2)
+	// threshold(2) parts(2) blockSize(4).
+	streamHeaderSize = 2 + 1 + 2 + 2 + 2 + 4
+
+	// blockHeaderSize is the fixed size, in bytes, of the length prefix
+	// written before each block of share data.
+	blockHeaderSize = 4
+)
+
+// streamHeader describes the layout shared by every block of a stream share.
+// It is written once, verbatim, at the start of the share.
+type streamHeader struct {
+	x         uint16
+	threshold uint16
+	parts     uint16
+	blockSize uint32
+}
+
+func writeStreamHeader(w io.Writer, h streamHeader) error {
+	buf := make([]byte, streamHeaderSize)
+	binary.BigEndian.PutUint16(buf[0:2], streamMagic)
+	buf[2] = streamVersion
+	binary.BigEndian.PutUint16(buf[3:5], h.x)
+	binary.BigEndian.PutUint16(buf[5:7], h.threshold)
+	binary.BigEndian.PutUint16(buf[7:9], h.parts)
+	binary.BigEndian.PutUint32(buf[9:13], h.blockSize)
+	_, err := w.Write(buf)
+	return err
+}
+
+func readStreamHeader(r io.Reader) (streamHeader, error) {
+	buf := make([]byte, streamHeaderSize)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return streamHeader{}, fmt.Errorf("failed to read share header: %w", err)
+	}
+
+	magic := binary.BigEndian.Uint16(buf[0:2])
+	if magic != streamMagic {
+		return streamHeader{}, fmt.Errorf("not a shamir stream share (bad magic)")
+	}
+	if version := buf[2]; version != streamVersion {
+		return streamHeader{}, fmt.Errorf("unsupported shamir stream version %d", version)
+	}
+
+	blockSize := binary.BigEndian.Uint32(buf[9:13])
+	if blockSize > MaxBlockSize {
+		return streamHeader{}, fmt.Errorf("share declares blockSize %d, exceeds maximum %d", blockSize, MaxBlockSize)
+	}
+
+	return streamHeader{
+		x:         binary.BigEndian.Uint16(buf[3:5]),
+		threshold: binary.BigEndian.Uint16(buf[5:7]),
+		parts:     binary.BigEndian.Uint16(buf[7:9]),
+		blockSize: blockSize,
+	}, nil
+}
+
+// streamWorkers returns the number of blocks SplitStream/CombineStream will
+// keep in flight at once. It is bounded by GOMAXPROCS, the same way Combine
+// bounds its per-word fan-out.
+func streamWorkers() int {
+	n := runtime.GOMAXPROCS(0)
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// rawBlock is a chunk of plaintext read from the secret, tagged with its
+// position in the stream so results can be written back out in order even
+// though a pool of workers processes blocks concurrently.
+type rawBlock struct {
+	seq  int
+	data []byte
+}
+
+type splitResult struct {
+	seq    int
+	shares [][]byte // shares[i] is the share data for writers[i]
+	err    error
+}
+
+// SplitStream reads secret from r in fixed-size blocks and writes `parts`
+// shares to writers, `threshold` of which are required to reconstruct the
+// secret with CombineStream. Unlike Split, SplitStream never holds the whole
+// secret or the whole set of shares in memory: it chunks the input into
+// DefaultBlockSize blocks, runs one polynomial per 16-bit word of a block,
+// and pipelines blocks through a bounded worker pool so memory stays
+// O(DefaultBlockSize * parts) regardless of the length of secret.
+func SplitStream(secret io.Reader, parts, threshold int, writers []io.Writer) error {
+	if parts < threshold {
+		return fmt.Errorf("parts cannot be less than threshold")
+	}
+	if parts > MaxPartsGF16 {
+		return fmt.Errorf("parts cannot exceed %d", MaxPartsGF16)
+	}
+	if threshold < 2 {
+		return fmt.Errorf("threshold must be at least 2")
+	}
+	if len(writers) != parts {
+		return fmt.Errorf("must provide exactly %d writers, got %d", parts, len(writers))
+	}
+
+	header := streamHeader{
+		threshold: uint16(threshold),
+		parts:     uint16(parts),
+		blockSize: uint32(DefaultBlockSize),
+	}
+	for i, w := range writers {
+		header.x = uint16(i) + 1
+		if err := writeStreamHeader(w, header); err != nil {
+			return fmt.Errorf("failed to write header for share %d: %w", i, err)
+		}
+	}
+
+	in := bufio.NewReaderSize(secret, DefaultBlockSize)
+
+	workers := streamWorkers()
+	jobs := make(chan rawBlock, workers)
+	results := make(chan splitResult, workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for blk := range jobs {
+				shares, err := splitBlock(blk.data, parts, threshold)
+				results <- splitResult{seq: blk.seq, shares: shares, err: err}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var readErr error
+	go func() {
+		defer close(jobs)
+		for seq := 0; ; seq++ {
+			buf := make([]byte, DefaultBlockSize)
+			n, err := io.ReadFull(in, buf)
+			if n > 0 {
+				jobs <- rawBlock{seq: seq, data: buf[:n]}
+			}
+			if err != nil {
+				if err != io.EOF && err != io.ErrUnexpectedEOF {
+					readErr = err
+				}
+				return
+			}
+		}
+	}()
+
+	// Reassemble out-of-order worker results into sequence so each writer
+	// sees its blocks in the order they were read, regardless of which
+	// worker finished them.
+	pending := make(map[int]splitResult)
+	next := 0
+	var writeErr error
+	for res := range results {
+		if writeErr != nil {
+			continue
+		}
+		pending[res.seq] = res
+		for {
+			r, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			if r.err != nil {
+				writeErr = r.err
+				break
+			}
+			for i, w := range writers {
+				if err := writeBlock(w, r.shares[i]); err != nil {
+					writeErr = fmt.Errorf("failed to write block to share %d: %w", i, err)
+					break
+				}
+			}
+		}
+	}
+
+	if readErr != nil {
+		return fmt.Errorf("failed to read secret: %w", readErr)
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+	return nil
+}
+
+// splitBlock runs the same polynomial-per-word construction as Split, but
+// against a single block's worth of words instead of the whole secret, and
+// returns a slice of raw share payloads (one per part) rather than framed
+// Parts.
+func splitBlock(block []byte, parts, threshold int) ([][]byte, error) {
+	padded := block
+	if len(padded)%2 != 0 {
+		padded = append(append([]byte{}, block...), 0)
+	}
+	words := byteToUint16(padded)
+
+	out16 := make([][]uint16, parts)
+	for idx := range out16 {
+		out16[idx] = make([]uint16, len(words))
+	}
+
+	for idx, val := range words {
+		p, err := makePolynomial(val, uint16(threshold-1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate polynomial: %w", err)
+		}
+		for i := 0; i < parts; i++ {
+			out16[i][idx] = p.evaluate(uint16(i) + 1)
+		}
+	}
+
+	shares := make([][]byte, parts)
+	for i, words := range out16 {
+		buf := make([]byte, blockHeaderSize+len(words)*2)
+		binary.BigEndian.PutUint32(buf[0:blockHeaderSize], uint32(len(block)))
+		copy(buf[blockHeaderSize:], uint16ToByte(words))
+		shares[i] = buf
+	}
+	return shares, nil
+}
+
+func writeBlock(w io.Writer, block []byte) error {
+	_, err := w.Write(block)
+	return err
+}
+
+// CombineStream reads the shares produced by SplitStream from readers and
+// writes the reconstructed secret to secret. It validates that every share
+// agrees on threshold, total parts and block size before reconstructing a
+// single byte, and rejects shares that disagree or that end early relative
+// to their peers.
+func CombineStream(readers []io.Reader, secret io.Writer) error {
+	if len(readers) < 2 {
+		return fmt.Errorf("less than two parts cannot be used to reconstruct the secret")
+	}
+
+	headers := make([]streamHeader, len(readers))
+	xSamples := make([]uint16, len(readers))
+	seen := make(map[uint16]struct{}, len(readers))
+	for i, r := range readers {
+		h, err := readStreamHeader(r)
+		if err != nil {
+			return fmt.Errorf("share %d: %w", i, err)
+		}
+		if i > 0 {
+			if h.threshold != headers[0].threshold || h.parts != headers[0].parts || h.blockSize != headers[0].blockSize {
+				return fmt.Errorf("share %d does not match share 0 (threshold/parts/blockSize)", i)
+			}
+		}
+		if _, exists := seen[h.x]; exists {
+			return fmt.Errorf("duplicate share index %d detected", h.x)
+		}
+		seen[h.x] = struct{}{}
+		headers[i] = h
+		xSamples[i] = h.x
+	}
+	if len(readers) < int(headers[0].threshold) {
+		return fmt.Errorf("at least %d shares are required, got %d", headers[0].threshold, len(readers))
+	}
+
+	for {
+		byteLens := make([]int, len(readers))
+		lenBuf := make([]byte, blockHeaderSize)
+
+		n, err := io.ReadFull(readers[0], lenBuf)
+		if err == io.EOF && n == 0 {
+			// Clean end of stream: every reader must agree.
+			for i := 1; i < len(readers); i++ {
+				if _, err := io.ReadFull(readers[i], lenBuf); err != io.EOF {
+					return fmt.Errorf("share %d has more blocks than share 0", i)
+				}
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("share 0: truncated block: %w", err)
+		}
+		byteLens[0] = int(binary.BigEndian.Uint32(lenBuf))
+		if byteLens[0] > int(headers[0].blockSize) {
+			return fmt.Errorf("share 0: block length %d exceeds negotiated block size %d", byteLens[0], headers[0].blockSize)
+		}
+
+		for i := 1; i < len(readers); i++ {
+			if _, err := io.ReadFull(readers[i], lenBuf); err != nil {
+				return fmt.Errorf("share %d: truncated or missing block: %w", i, err)
+			}
+			byteLens[i] = int(binary.BigEndian.Uint32(lenBuf))
+			if byteLens[i] != byteLens[0] {
+				return fmt.Errorf("share %d block length disagrees with share 0", i)
+			}
+		}
+
+		numWords := (byteLens[0] + 1) / 2
+		ySamples := make([][]uint16, len(readers))
+		for i, r := range readers {
+			wordBuf := make([]byte, numWords*2)
+			if _, err := io.ReadFull(r, wordBuf); err != nil {
+				return fmt.Errorf("share %d: truncated block data: %w", i, err)
+			}
+			ySamples[i] = byteToUint16(wordBuf)
+		}
+
+		out := make([]uint16, numWords)
+		sample := make([]uint16, len(readers))
+		for idx := range out {
+			for i := range ySamples {
+				sample[i] = ySamples[i][idx]
+			}
+			out[idx] = interpolatePolynomial(xSamples, sample, 0)
+		}
+
+		outBytes := uint16ToByte(out)
+		if _, err := secret.Write(outBytes[:byteLens[0]]); err != nil {
+			return fmt.Errorf("failed to write reconstructed block: %w", err)
+		}
+	}
+}
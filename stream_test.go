@@ -0,0 +1,122 @@
+package shamir
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+func TestSplitCombineStream(t *testing.T) {
+	secret := make([]byte, 3*DefaultBlockSize+17)
+	if _, err := rand.Read(secret); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	parts, threshold := 5, 3
+
+	bufs := make([]*bytes.Buffer, parts)
+	writers := make([]io.Writer, parts)
+	for i := range bufs {
+		bufs[i] = new(bytes.Buffer)
+		writers[i] = bufs[i]
+	}
+
+	if err := SplitStream(bytes.NewReader(secret), parts, threshold, writers); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	readers := []io.Reader{bufs[0], bufs[2], bufs[4]}
+	var out bytes.Buffer
+	if err := CombineStream(readers, &out); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	if !bytes.Equal(out.Bytes(), secret) {
+		t.Fatalf("bad: reconstructed secret does not match")
+	}
+}
+
+func TestSplitStream_invalid(t *testing.T) {
+	writers := []io.Writer{new(bytes.Buffer), new(bytes.Buffer)}
+
+	if err := SplitStream(bytes.NewReader(nil), 2, 3, writers); err == nil {
+		t.Fatalf("expect error")
+	}
+
+	if err := SplitStream(bytes.NewReader(nil), 2, 2, writers[:1]); err == nil {
+		t.Fatalf("expect error")
+	}
+}
+
+func TestCombineStream_invalid(t *testing.T) {
+	if err := CombineStream([]io.Reader{new(bytes.Buffer)}, new(bytes.Buffer)); err == nil {
+		t.Fatalf("expect error")
+	}
+
+	secret := []byte("this is a short secret")
+	parts, threshold := 4, 3
+
+	bufs := make([]*bytes.Buffer, parts)
+	writers := make([]io.Writer, parts)
+	for i := range bufs {
+		bufs[i] = new(bytes.Buffer)
+		writers[i] = bufs[i]
+	}
+	if err := SplitStream(bytes.NewReader(secret), parts, threshold, writers); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	shareBytes := make([][]byte, parts)
+	for i, buf := range bufs {
+		shareBytes[i] = buf.Bytes()
+	}
+
+	// Below threshold must be rejected.
+	readers := []io.Reader{bytes.NewReader(shareBytes[0]), bytes.NewReader(shareBytes[1])}
+	if err := CombineStream(readers, new(bytes.Buffer)); err == nil {
+		t.Fatalf("expect error")
+	}
+
+	// Truncating one share's block data mid-stream must be detected rather
+	// than silently reconstructing garbage.
+	truncated := bytes.NewReader(shareBytes[2][:streamHeaderSize+2])
+	readers = []io.Reader{
+		bytes.NewReader(shareBytes[0]),
+		bytes.NewReader(shareBytes[1]),
+		truncated,
+	}
+	if err := CombineStream(readers, new(bytes.Buffer)); err == nil {
+		t.Fatalf("expect error")
+	}
+
+	// A block length prefix larger than the negotiated block size must be
+	// rejected immediately, before it is ever used to size an allocation.
+	corrupted := append([]byte{}, shareBytes[0]...)
+	binary.BigEndian.PutUint32(corrupted[streamHeaderSize:streamHeaderSize+blockHeaderSize], 0xFFFFFFF0)
+	readers = []io.Reader{
+		bytes.NewReader(corrupted),
+		bytes.NewReader(shareBytes[1]),
+		bytes.NewReader(shareBytes[2]),
+	}
+	if err := CombineStream(readers, new(bytes.Buffer)); err == nil {
+		t.Fatalf("expect error")
+	}
+
+	// A header declaring a blockSize above MaxBlockSize must be rejected
+	// before it is ever used to bound, let alone size, an allocation - even
+	// if every share agrees on the inflated value and the length prefix
+	// that follows stays under it.
+	oversizedHeader := append([]byte{}, shareBytes[0][:streamHeaderSize]...)
+	binary.BigEndian.PutUint32(oversizedHeader[9:streamHeaderSize], MaxBlockSize+1)
+	oversizedShare := append(oversizedHeader, shareBytes[0][streamHeaderSize:]...)
+	readers = []io.Reader{
+		bytes.NewReader(oversizedShare),
+		bytes.NewReader(shareBytes[1]),
+		bytes.NewReader(shareBytes[2]),
+	}
+	if err := CombineStream(readers, new(bytes.Buffer)); err == nil {
+		t.Fatalf("expect error")
+	}
+}
@@ -0,0 +1,292 @@
+// Package vss layers Feldman verifiable secret sharing on top of the
+// GF(2^16) Shamir core in package shamir. In addition to the (x, y) share
+// that shamir.Combine needs to reconstruct the secret, the dealer publishes
+// a small set of public commitments that let any shareholder check that
+// their share lies on the same polynomial as everyone else's, without
+// learning the secret or trusting the dealer.
+//
+// crypto/ecdh deliberately hides raw point arithmetic, but Feldman
+// verification needs to add and scalar-multiply commitments directly
+// (C_j^{x^j} terms summed across j), so this package drives NIST P-256
+// through crypto/elliptic instead.
+//
+// Unlike the request this package was first built against, reconstruction
+// here does not hand verified shares to shamir.Combine. Combine's
+// Lagrange interpolation runs in GF(2^16), which has no relationship to
+// the Z_q arithmetic the commitments are built from: a share that checks
+// out against the commitments in Z_q says nothing about what a
+// GF(2^16)-domain sibling value would evaluate to, so carrying both and
+// verifying only one is not a binding at all. Instead, Part carries a
+// single Y value per word, evaluated and verified entirely in Z_q, and
+// CombineVerified reconstructs the secret with its own Z_q Lagrange
+// interpolation.
+package vss
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/PureStorage-OpenConnect/shamir-16"
+	"github.com/PureStorage-OpenConnect/shamir-16/gf16"
+)
+
+var curve = elliptic.P256()
+
+// point is a commitment or intermediate value in the P-256 group, in affine
+// coordinates. The identity element is represented as (0, 0), matching the
+// convention crypto/elliptic's CurveParams methods use internally.
+type point struct {
+	x, y *big.Int
+}
+
+func identity() point {
+	return point{big.NewInt(0), big.NewInt(0)}
+}
+
+func (p point) add(o point) point {
+	x, y := curve.Add(p.x, p.y, o.x, o.y)
+	return point{x, y}
+}
+
+func (p point) scalarMult(k *big.Int) point {
+	x, y := curve.ScalarMult(p.x, p.y, k.Bytes())
+	return point{x, y}
+}
+
+func scalarBaseMult(k *big.Int) point {
+	x, y := curve.ScalarBaseMult(k.Bytes())
+	return point{x, y}
+}
+
+// Commitment holds the Feldman commitments C_0..C_{t-1} = g^{a_0}..g^{a_{t-1}}
+// for a single 16-bit word's polynomial, where a_0 is the word's secret
+// value and a_1..a_{t-1} are its random coefficients lifted into Z_q.
+// SplitVerifiable returns one Commitment per word of the secret, in word
+// order.
+type Commitment struct {
+	points []point
+}
+
+// Part is a single shareholder's verifiable share. Y holds, for every word
+// of the secret, this shareholder's evaluation in Z_q of the word's
+// polynomial; VerifyShare checks Y against the commitments, and
+// CombineVerified reconstructs the secret from Y directly once every share
+// has checked out, so there is no unverified value smuggled past
+// verification.
+type Part struct {
+	X uint16
+	Y []*big.Int
+}
+
+// liftWord injectively encodes a GF(2^16) element into Z_q by treating it
+// as the small non-negative integer it already is: q is astronomically
+// larger than 2^16, so no reduction is needed.
+func liftWord(w uint16) *big.Int {
+	return big.NewInt(int64(w))
+}
+
+// wordPolynomial is the coefficient set the dealer samples for a single
+// word of the secret: Coefficients[0] is the word's value, the rest are
+// random. gf16.Random is reused purely as a source of threshold-1
+// uniformly random 16-bit coefficients (so the resulting commitments carry
+// exactly as much entropy as a GF(2^16) Shamir share would); the
+// polynomial itself is only ever evaluated in Z_q, via evaluateZQ.
+type wordPolynomial struct {
+	gf16.Polynomial
+}
+
+func makeWordPolynomial(intercept uint16, degree int) (wordPolynomial, error) {
+	p, err := gf16.Random(gf16.Element(intercept), degree, rand.Reader)
+	if err != nil {
+		return wordPolynomial{}, err
+	}
+	return wordPolynomial{p}, nil
+}
+
+func (p wordPolynomial) evaluateZQ(x *big.Int, q *big.Int) *big.Int {
+	degree := len(p.Coefficients) - 1
+	out := liftWord(uint16(p.Coefficients[degree]))
+	for i := degree - 1; i >= 0; i-- {
+		out = new(big.Int).Mod(new(big.Int).Add(new(big.Int).Mul(out, x), liftWord(uint16(p.Coefficients[i]))), q)
+	}
+	return out
+}
+
+func (p wordPolynomial) commitments() Commitment {
+	c := Commitment{points: make([]point, len(p.Coefficients))}
+	for j, a := range p.Coefficients {
+		c.points[j] = scalarBaseMult(liftWord(uint16(a)))
+	}
+	return c
+}
+
+// SplitVerifiable behaves like shamir.Split, but additionally returns a
+// Commitment per word of the secret so that VerifyShare can later confirm
+// each returned Part lies on the polynomial the dealer actually used.
+func SplitVerifiable(secret shamir.Secret, parts, threshold int) ([]Part, []Commitment, error) {
+	if parts < threshold {
+		return nil, nil, fmt.Errorf("parts cannot be less than threshold")
+	}
+	if parts > shamir.MaxPartsGF16 {
+		return nil, nil, fmt.Errorf("parts cannot exceed %d", shamir.MaxPartsGF16)
+	}
+	if threshold < 2 {
+		return nil, nil, fmt.Errorf("threshold must be at least 2")
+	}
+	if len(secret) == 0 {
+		return nil, nil, fmt.Errorf("cannot split an empty secret")
+	}
+	if len(secret)%2 != 0 {
+		return nil, nil, fmt.Errorf("cannot split odd length secret")
+	}
+
+	q := curve.Params().N
+	secret16 := bytesToWords(secret)
+
+	yShares := make([][]*big.Int, parts)
+	for i := range yShares {
+		yShares[i] = make([]*big.Int, len(secret16))
+	}
+
+	commitments := make([]Commitment, len(secret16))
+
+	for idx, word := range secret16 {
+		p, err := makeWordPolynomial(word, threshold-1)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate polynomial: %w", err)
+		}
+		commitments[idx] = p.commitments()
+
+		for i := 0; i < parts; i++ {
+			x := uint16(i) + 1
+			yShares[i][idx] = p.evaluateZQ(big.NewInt(int64(x)), q)
+		}
+	}
+
+	out := make([]Part, parts)
+	for i := range out {
+		out[i] = Part{
+			X: uint16(i) + 1,
+			Y: yShares[i],
+		}
+	}
+
+	return out, commitments, nil
+}
+
+// VerifyShare checks that part lies on the polynomials committed to by
+// commitments, i.e. that g^{part.Y[idx]} == product_j C_j^{x^j} for every
+// word idx, without reconstructing or otherwise learning the secret. It
+// returns an error describing the first word that fails to verify.
+func VerifyShare(part Part, commitments []Commitment) error {
+	if len(part.Y) != len(commitments) {
+		return fmt.Errorf("share has %d words, commitments cover %d", len(part.Y), len(commitments))
+	}
+
+	x := big.NewInt(int64(part.X))
+	q := curve.Params().N
+
+	for idx, c := range commitments {
+		lhs := scalarBaseMult(part.Y[idx])
+
+		rhs := identity()
+		xPow := big.NewInt(1)
+		for _, Cj := range c.points {
+			rhs = rhs.add(Cj.scalarMult(xPow))
+			xPow = new(big.Int).Mod(new(big.Int).Mul(xPow, x), q)
+		}
+
+		if lhs.x.Cmp(rhs.x) != 0 || lhs.y.Cmp(rhs.y) != 0 {
+			return fmt.Errorf("share fails verification at word %d", idx)
+		}
+	}
+	return nil
+}
+
+// CombineVerified checks every part against commitments with VerifyShare
+// before reconstructing the secret, so a single tampered or forged share
+// is rejected instead of silently producing the wrong secret. Unlike
+// shamir.Combine, interpolation happens in Z_q against the exact Y values
+// that were just verified, so there is no gap between what was checked and
+// what was reconstructed from.
+func CombineVerified(parts []Part, commitments []Commitment) (shamir.Secret, error) {
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("less than two parts cannot be used to reconstruct the secret")
+	}
+
+	for i, part := range parts {
+		if err := VerifyShare(part, commitments); err != nil {
+			return nil, fmt.Errorf("part %d: %w", i, err)
+		}
+	}
+
+	q := curve.Params().N
+	numWords := len(commitments)
+	secret16 := make([]uint16, numWords)
+
+	xs := make([]*big.Int, len(parts))
+	checkMap := make(map[uint16]struct{}, len(parts))
+	for i, part := range parts {
+		if _, exists := checkMap[part.X]; exists {
+			return nil, fmt.Errorf("duplicate part detected")
+		}
+		checkMap[part.X] = struct{}{}
+		xs[i] = big.NewInt(int64(part.X))
+	}
+
+	for idx := 0; idx < numWords; idx++ {
+		ys := make([]*big.Int, len(parts))
+		for i, part := range parts {
+			ys[i] = part.Y[idx]
+		}
+		word := interpolateAtZeroZQ(xs, ys, q)
+		if word.Sign() < 0 || word.Cmp(big.NewInt(1<<16)) >= 0 {
+			return nil, fmt.Errorf("word %d: reconstructed value out of range, shares do not agree", idx)
+		}
+		secret16[idx] = uint16(word.Uint64())
+	}
+
+	return shamir.Secret(wordsToBytes(secret16)), nil
+}
+
+// interpolateAtZeroZQ evaluates the unique degree-(len(xs)-1) polynomial
+// through (xs[i], ys[i]) at x=0, i.e. recovers the polynomial's intercept,
+// using Lagrange interpolation in Z_q.
+func interpolateAtZeroZQ(xs, ys []*big.Int, q *big.Int) *big.Int {
+	result := big.NewInt(0)
+	for i := range xs {
+		num := big.NewInt(1)
+		den := big.NewInt(1)
+		for j := range xs {
+			if i == j {
+				continue
+			}
+			num.Mod(new(big.Int).Mul(num, new(big.Int).Neg(xs[j])), q)
+			den.Mod(new(big.Int).Mul(den, new(big.Int).Sub(xs[i], xs[j])), q)
+		}
+		term := new(big.Int).Mul(ys[i], num)
+		term.Mul(term, new(big.Int).ModInverse(den, q))
+		result.Add(result, term)
+		result.Mod(result, q)
+	}
+	return result
+}
+
+func bytesToWords(b []byte) []uint16 {
+	out := make([]uint16, len(b)/2)
+	for i := range out {
+		out[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+	}
+	return out
+}
+
+func wordsToBytes(w []uint16) []byte {
+	out := make([]byte, len(w)*2)
+	for i, v := range w {
+		out[2*i] = byte(v >> 8)
+		out[2*i+1] = byte(v)
+	}
+	return out
+}
@@ -0,0 +1,56 @@
+package vss
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/PureStorage-OpenConnect/shamir-16"
+)
+
+func TestSplitVerifiable(t *testing.T) {
+	secret := shamir.Secret("this is a test secret!")
+
+	parts, commitments, err := SplitVerifiable(secret, 5, 3)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(parts) != 5 {
+		t.Fatalf("bad: %v", parts)
+	}
+
+	for _, part := range parts {
+		if err := VerifyShare(part, commitments); err != nil {
+			t.Fatalf("expected valid share to verify: %v", err)
+		}
+	}
+
+	recomb, err := CombineVerified(parts[:3], commitments)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !bytes.Equal(recomb, secret) {
+		t.Fatalf("bad: %v %v", recomb, secret)
+	}
+}
+
+func TestVerifyShare_tampered(t *testing.T) {
+	secret := shamir.Secret("test")
+
+	parts, commitments, err := SplitVerifiable(secret, 5, 3)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	tampered := parts[0]
+	corruptedY := new(big.Int).Xor(tampered.Y[0], big.NewInt(1))
+	tampered.Y = append([]*big.Int{corruptedY}, tampered.Y[1:]...)
+
+	if err := VerifyShare(tampered, commitments); err == nil {
+		t.Fatalf("expected tampered share to fail verification")
+	}
+
+	if _, err := CombineVerified(append([]Part{tampered}, parts[1:3]...), commitments); err == nil {
+		t.Fatalf("expected CombineVerified to reject a tampered share")
+	}
+}